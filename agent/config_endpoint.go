@@ -1,32 +1,75 @@
 package agent
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/mitchellh/mapstructure"
+	"sigs.k8s.io/yaml"
 )
 
+// configApplyBatchPath is the path segment (relative to the "/v1/config/"
+// prefix route that Config is registered under) that identifies the
+// declarative multi-document apply/dry-run endpoint added alongside
+// ConfigApplyBatch. A bare POST to that prefix with this suffix is how
+// clients reach it, since "/v1/config/apply" falls under the same
+// registered prefix as GET/DELETE rather than the exact-match "/v1/config"
+// route ConfigApply is registered on.
+const configApplyBatchPath = "apply"
+
 // Config switches on the different CRUD operations for config entries.
 func (s *HTTPServer) Config(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case "GET":
 		return s.configGet(resp, req)
 
+	case "POST":
+		return s.configPost(resp, req)
+
 	case "DELETE":
 		return s.configDelete(resp, req)
 
 	default:
-		return nil, MethodNotAllowedError{req.Method, []string{"GET", "DELETE"}}
+		return nil, MethodNotAllowedError{req.Method, []string{"GET", "POST", "DELETE"}}
+	}
+}
+
+// configPost handles POST requests under the "/v1/config/" prefix. Today the
+// only such request is "/v1/config/apply", which it routes to
+// ConfigApplyBatch; anything else is a 400 since single-entry apply is
+// registered separately at the exact "/v1/config" route (ConfigApply).
+func (s *HTTPServer) configPost(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if isConfigApplyBatchPath(req.URL.Path) {
+		return s.ConfigApplyBatch(resp, req)
 	}
+
+	resp.WriteHeader(http.StatusBadRequest)
+	fmt.Fprintf(resp, "Unsupported config endpoint: %s", req.URL.Path)
+	return nil, nil
+}
+
+// isConfigApplyBatchPath reports whether reqPath is the "/v1/config/apply"
+// multi-document apply endpoint, as opposed to some other path sharing the
+// "/v1/config/" prefix route.
+func isConfigApplyBatchPath(reqPath string) bool {
+	return strings.TrimPrefix(reqPath, "/v1/config/") == configApplyBatchPath
 }
 
 // configGet gets either a specific config entry, or lists all config entries
-// of a kind if no name is provided.
+// of a kind if no name is provided. s.parse already wires the blocking-query
+// machinery through args.QueryOptions (honoring `?index=` and `?wait=`), and
+// the RPC reply's embedded QueryMeta is translated into the `X-Consul-Index`
+// response header the same way every other blocking endpoint works.
 func (s *HTTPServer) configGet(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var args structs.ConfigEntryQuery
 	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
@@ -34,33 +77,99 @@ func (s *HTTPServer) configGet(resp http.ResponseWriter, req *http.Request) (int
 	}
 	pathArgs := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/v1/config/"), "/", 2)
 
+	rpc := "ConfigEntry.List"
 	switch len(pathArgs) {
 	case 2:
 		// Both kind/name provided.
 		args.Kind = pathArgs[0]
 		args.Name = pathArgs[1]
-
-		var reply structs.IndexedConfigEntries
-		if err := s.agent.RPC("ConfigEntry.Get", &args, &reply); err != nil {
-			return nil, err
-		}
-
-		return reply, nil
+		rpc = "ConfigEntry.Get"
 	case 1:
 		// Only kind provided, list entries.
 		args.Kind = pathArgs[0]
-
-		var reply structs.IndexedConfigEntries
-		if err := s.agent.RPC("ConfigEntry.List", &args, &reply); err != nil {
-			return nil, err
-		}
-
-		return reply, nil
 	default:
 		resp.WriteHeader(http.StatusBadRequest)
 		fmt.Fprintf(resp, "Must provide either a kind or both kind and name")
 		return nil, nil
 	}
+
+	if parseStream(req) {
+		return nil, s.configStream(resp, req, rpc, args)
+	}
+
+	var reply structs.IndexedConfigEntries
+	if err := s.agent.RPC(rpc, &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
+// configStream upgrades the request to a Server-Sent Events response and
+// re-issues the given blocking RPC every time the result advances past the
+// last index it returned, emitting a JSON IndexedConfigEntries frame for
+// each one. This lets callers like xDS bridges or GitOps controllers react to
+// service-defaults/service-resolver changes without polling. The stream ends
+// when the client disconnects.
+func (s *HTTPServer) configStream(resp http.ResponseWriter, req *http.Request, rpc string, args structs.ConfigEntryQuery) error {
+	flusher, ok := resp.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("streaming not supported by this connection")
+	}
+
+	// Run the first RPC before committing to a 200 and the SSE headers, so a
+	// bad kind, ACL denial, or other RPC failure surfaces as a normal HTTP
+	// error like every other branch of this file, instead of being
+	// downgraded to an in-band "event: error" frame on a misleading 200.
+	var reply structs.IndexedConfigEntries
+	if err := s.agent.RPC(rpc, &args, &reply); err != nil {
+		return err
+	}
+
+	resp.Header().Set("Content-Type", "text/event-stream")
+	resp.Header().Set("Cache-Control", "no-cache")
+	resp.Header().Set("Connection", "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+
+	if err := writeConfigStreamFrame(resp, flusher, reply); err != nil {
+		return err
+	}
+	args.QueryOptions.MinQueryIndex = reply.Index
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return nil
+		default:
+		}
+
+		var reply structs.IndexedConfigEntries
+		if err := s.agent.RPC(rpc, &args, &reply); err != nil {
+			fmt.Fprintf(resp, "event: error\ndata: %s\n\n", err.Error())
+			flusher.Flush()
+			return nil
+		}
+
+		if reply.Index > args.QueryOptions.MinQueryIndex {
+			if err := writeConfigStreamFrame(resp, flusher, reply); err != nil {
+				return err
+			}
+			args.QueryOptions.MinQueryIndex = reply.Index
+		}
+	}
+}
+
+// writeConfigStreamFrame marshals reply as JSON and writes it as a single SSE
+// "data:" frame, flushing it to the client immediately.
+func writeConfigStreamFrame(resp http.ResponseWriter, flusher http.Flusher, reply structs.IndexedConfigEntries) error {
+	frame, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(resp, "data: %s\n\n", frame)
+	flusher.Flush()
+	return nil
 }
 
 // configDelete deletes the given config entry.
@@ -84,7 +193,14 @@ func (s *HTTPServer) configDelete(resp http.ResponseWriter, req *http.Request) (
 	}
 	args.Entry = entry
 
-	var reply struct{}
+	casIndex, cas, err := parseConfigEntryCAS(req)
+	if err != nil {
+		return nil, BadRequestError{Reason: err.Error()}
+	}
+	args.CAS = cas
+	args.CASIndex = casIndex
+
+	var reply bool
 	if err := s.agent.RPC("ConfigEntry.Delete", &args, &reply); err != nil {
 		return nil, err
 	}
@@ -92,21 +208,97 @@ func (s *HTTPServer) configDelete(resp http.ResponseWriter, req *http.Request) (
 	return reply, nil
 }
 
+// parseConfigEntryCAS looks for a `?cas=<index>` query parameter, or the
+// equivalent `If-Match: <index>` / `If-None-Match: *` headers, and returns
+// the index to check against along with whether a CAS check was requested
+// at all. A CASIndex of 0 (from `?cas=0` or `If-None-Match: *`) means the
+// write should only succeed if the entry does not already exist.
+func parseConfigEntryCAS(req *http.Request) (uint64, bool, error) {
+	if v := req.URL.Query().Get("cas"); v != "" {
+		idx, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid cas index: %v", err)
+		}
+		return idx, true, nil
+	}
+	if req.Header.Get("If-None-Match") == "*" {
+		return 0, true, nil
+	}
+	if v := req.Header.Get("If-Match"); v != "" {
+		idx, err := strconv.ParseUint(strings.Trim(v, `"`), 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid If-Match index: %v", err)
+		}
+		return idx, true, nil
+	}
+	return 0, false, nil
+}
+
+// configValidationMode controls how decodeConfigEntry treats unknown fields
+// in the request body, e.g. a misspelled `Protcol` instead of `Protocol`.
+type configValidationMode string
+
+const (
+	configValidationStrict configValidationMode = "strict"
+	configValidationWarn   configValidationMode = "warn"
+	configValidationLax    configValidationMode = "lax"
+)
+
+// parseConfigValidationMode reads the `?validation=` query parameter,
+// defaulting to "warn" to match today's historical behavior of silently
+// accepting unknown fields, minus the silence.
+func parseConfigValidationMode(req *http.Request) (configValidationMode, error) {
+	v := req.URL.Query().Get("validation")
+	if v == "" {
+		return configValidationWarn, nil
+	}
+	switch configValidationMode(v) {
+	case configValidationStrict, configValidationWarn, configValidationLax:
+		return configValidationMode(v), nil
+	default:
+		return "", fmt.Errorf("invalid validation mode %q, must be one of strict, warn, lax", v)
+	}
+}
+
+// parseDryRun reports whether the request asked for a dry run via
+// `?dry-run=true`.
+func parseDryRun(req *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(req.URL.Query().Get("dry-run"))
+	return dryRun
+}
+
+// parseStream reports whether the request asked to upgrade to a streaming
+// response via `?stream=true`.
+func parseStream(req *http.Request) bool {
+	stream, _ := strconv.ParseBool(req.URL.Query().Get("stream"))
+	return stream
+}
+
 // decodeBody is used to decode a JSON request body
-func decodeConfigBody(req *http.Request) (structs.ConfigEntry, error) {
+func decodeConfigBody(req *http.Request, mode configValidationMode) (structs.ConfigEntry, []string, error) {
 	// This generally only happens in tests since real HTTP requests set
 	// a non-nil body with no content. We guard against it anyways to prevent
 	// a panic. The EOF response is the same behavior as an empty reader.
 	if req.Body == nil {
-		return nil, io.EOF
+		return nil, nil, io.EOF
 	}
 
 	var raw map[string]interface{}
 	dec := json.NewDecoder(req.Body)
 	if err := dec.Decode(&raw); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	return decodeConfigEntry(raw, mode)
+}
+
+// decodeConfigEntry decodes a raw, already-JSON-or-YAML-unmarshaled config
+// entry into its concrete structs.ConfigEntry type. In "strict" mode,
+// unknown/misspelled fields (at any nesting level) cause an error listing
+// them. In "warn" mode they're returned as warnings instead of silently
+// dropped. In "lax" mode they're dropped with no feedback at all, matching
+// this endpoint's original behavior.
+func decodeConfigEntry(raw map[string]interface{}, mode configValidationMode) (structs.ConfigEntry, []string, error) {
 	var entry structs.ConfigEntry
 
 	kindVal, ok := raw["Kind"]
@@ -114,70 +306,45 @@ func decodeConfigBody(req *http.Request) (structs.ConfigEntry, error) {
 		kindVal, ok = raw["kind"]
 	}
 	if !ok {
-		return nil, fmt.Errorf("Payload does not contain a kind/Kind key at the top level")
+		return nil, nil, fmt.Errorf("Payload does not contain a kind/Kind key at the top level")
 	}
 
 	if kindStr, ok := kindVal.(string); ok {
 		newEntry, err := structs.MakeConfigEntry(kindStr, "")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		entry = newEntry
 	} else {
-		return nil, fmt.Errorf("Kind value in payload is not a string")
+		return nil, nil, fmt.Errorf("Kind value in payload is not a string")
 	}
 
+	var md mapstructure.Metadata
 	decodeConf := &mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
 			stringToReadableDurationFunc(),
 		),
-		Result: &entry,
+		Metadata:    &md,
+		ErrorUnused: mode == configValidationStrict,
+		Result:      &entry,
 	}
 
 	decoder, err := mapstructure.NewDecoder(decodeConf)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return entry, decoder.Decode(raw)
-}
-
-func decodeConfigEntry(raw map[string]interface{}) (structs.ConfigEntry, error) {
-	var entry structs.ConfigEntry
-
-	kindVal, ok := raw["Kind"]
-	if !ok {
-		kindVal, ok = raw["kind"]
-	}
-	if !ok {
-		return nil, fmt.Errorf("Payload does not contain a kind/Kind key at the top level")
+	if err := decoder.Decode(raw); err != nil {
+		return nil, nil, err
 	}
 
-	if kindStr, ok := kindVal.(string); ok {
-		newEntry, err := structs.MakeConfigEntry(kindStr, "")
-		if err != nil {
-			return nil, err
-		}
-		entry = newEntry
-	} else {
-		return nil, fmt.Errorf("Kind value in payload is not a string")
+	var warnings []string
+	if mode == configValidationWarn && len(md.Unused) > 0 {
+		warnings = md.Unused
 	}
 
-	decodeConf := &mapstructure.DecoderConfig{
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			mapstructure.StringToTimeDurationHookFunc(),
-			stringToReadableDurationFunc(),
-		),
-		Result: &entry,
-	}
-
-	decoder, err := mapstructure.NewDecoder(decodeConf)
-	if err != nil {
-		return nil, err
-	}
-
-	return entry, decoder.Decode(raw)
+	return entry, warnings, nil
 }
 
 // ConfigCreate applies the given config entry update.
@@ -188,17 +355,277 @@ func (s *HTTPServer) ConfigApply(resp http.ResponseWriter, req *http.Request) (i
 	s.parseDC(req, &args.Datacenter)
 	s.parseToken(req, &args.Token)
 
+	mode, err := parseConfigValidationMode(req)
+	if err != nil {
+		return nil, BadRequestError{Reason: err.Error()}
+	}
+
 	var raw map[string]interface{}
 	if err := decodeBody(req, &raw, nil); err != nil {
 		return nil, BadRequestError{Reason: fmt.Sprintf("Request decoding failed: %v", err)}
 	}
 
-	if entry, err := decodeConfigEntry(raw); err == nil {
-		args.Entry = entry
-	} else {
+	entry, warnings, err := decodeConfigEntry(raw, mode)
+	if err != nil {
+		return nil, BadRequestError{Reason: fmt.Sprintf("Request decoding failed: %v", err)}
+	}
+	args.Entry = entry
+	if len(warnings) > 0 {
+		resp.Header().Set("X-Consul-Config-Warnings", strings.Join(warnings, ", "))
+	}
+
+	casIndex, cas, err := parseConfigEntryCAS(req)
+	if err != nil {
+		return nil, BadRequestError{Reason: err.Error()}
+	}
+	args.CAS = cas
+	args.CASIndex = casIndex
+
+	var reply bool
+	if err := s.agent.RPC("ConfigEntry.Apply", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// ConfigApplyBatch is the handler for POST /v1/config/apply. Unlike
+// ConfigApply, which decodes a single JSON config entry, this accepts either
+// a single JSON entry or a `Content-Type: application/yaml` body containing
+// one or more entries separated by `---`, mirroring the `kubectl apply -f`
+// declarative-apply workflow. All entries are applied atomically in a single
+// Raft transaction. With `?dry-run=true` no write happens at all; instead
+// each entry is validated and compared against what's currently stored, and
+// a per-entry created/updated/unchanged report (with a diff) is returned.
+func (s *HTTPServer) ConfigApplyBatch(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var dc string
+	s.parseDC(req, &dc)
+	var token string
+	s.parseToken(req, &token)
+
+	mode, err := parseConfigValidationMode(req)
+	if err != nil {
+		return nil, BadRequestError{Reason: err.Error()}
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, BadRequestError{Reason: fmt.Sprintf("Failed to read request body: %v", err)}
+	}
+
+	entries, warnings, err := decodeConfigEntries(req.Header.Get("Content-Type"), body, mode)
+	if err != nil {
 		return nil, BadRequestError{Reason: fmt.Sprintf("Request decoding failed: %v", err)}
 	}
+	if len(warnings) > 0 {
+		resp.Header().Set("X-Consul-Config-Warnings", strings.Join(warnings, ", "))
+	}
+
+	if parseDryRun(req) {
+		return s.configApplyDryRun(dc, token, entries)
+	}
+
+	ops := make([]structs.ConfigEntryTxnOp, len(entries))
+	for i, entry := range entries {
+		ops[i] = structs.ConfigEntryTxnOp{Op: structs.ConfigEntryUpsert, Entry: entry}
+	}
+
+	args := structs.ConfigEntryTxnRequest{Datacenter: dc, Token: token, Ops: ops}
+	var reply structs.ConfigEntryTxnResponse
+	if err := s.agent.RPC("ConfigEntry.ApplyBatch", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
 
-	var reply struct{}
-	return nil, s.agent.RPC("ConfigEntry.Apply", &args, &reply)
+// decodeConfigEntries splits a request body into one or more decoded config
+// entries, along with any unknown-field warnings collected along the way (see
+// configValidationMode). A YAML content type is split into `---`-separated
+// documents; any other content type is decoded as a single JSON entry.
+func decodeConfigEntries(contentType string, body []byte, mode configValidationMode) ([]structs.ConfigEntry, []string, error) {
+	// Content-Type headers may carry parameters, e.g.
+	// "application/yaml; charset=utf-8", so compare the parsed media type
+	// rather than the raw header value.
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+
+	switch mediaType {
+	case "application/yaml", "application/x-yaml":
+		var entries []structs.ConfigEntry
+		var warnings []string
+		for _, doc := range splitYAMLDocs(body) {
+			if len(bytes.TrimSpace(doc)) == 0 {
+				continue
+			}
+			jsonDoc, err := yaml.YAMLToJSON(doc)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid YAML document: %v", err)
+			}
+			var raw map[string]interface{}
+			if err := json.Unmarshal(jsonDoc, &raw); err != nil {
+				return nil, nil, err
+			}
+			entry, entryWarnings, err := decodeConfigEntry(raw, mode)
+			if err != nil {
+				return nil, nil, err
+			}
+			entries = append(entries, entry)
+			warnings = append(warnings, entryWarnings...)
+		}
+		if len(entries) == 0 {
+			return nil, nil, fmt.Errorf("no config entries found in request body")
+		}
+		return entries, warnings, nil
+	default:
+		var raw map[string]interface{}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, nil, err
+		}
+		entry, warnings, err := decodeConfigEntry(raw, mode)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []structs.ConfigEntry{entry}, warnings, nil
+	}
+}
+
+// splitYAMLDocs splits a byte stream on lines containing only "---", the
+// standard YAML document separator.
+func splitYAMLDocs(body []byte) [][]byte {
+	var docs [][]byte
+	var current bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			docs = append(docs, append([]byte(nil), current.Bytes()...))
+			current.Reset()
+			continue
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	docs = append(docs, append([]byte(nil), current.Bytes()...))
+	return docs
+}
+
+// configEntryApplyResult describes what a dry-run apply would have done to a
+// single config entry, or why it couldn't be applied at all.
+type configEntryApplyResult struct {
+	Kind   string
+	Name   string
+	Action string // "created", "updated", or "unchanged"
+	Diff   string `json:",omitempty"`
+	Error  string `json:",omitempty"`
+}
+
+// configApplyDryRun runs full validation (kind resolution already happened
+// during decode; this normalizes and validates each entry the same way a
+// real apply would) and compares the result against the current state
+// without writing anything to Raft, reporting what would change.
+func (s *HTTPServer) configApplyDryRun(dc, token string, entries []structs.ConfigEntry) ([]configEntryApplyResult, error) {
+	results := make([]configEntryApplyResult, 0, len(entries))
+	for _, entry := range entries {
+		result := configEntryApplyResult{Kind: entry.GetKind(), Name: entry.GetName()}
+
+		if err := entry.Normalize(); err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		if err := entry.Validate(); err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		getArgs := structs.ConfigEntryQuery{
+			Kind:       entry.GetKind(),
+			Name:       entry.GetName(),
+			Datacenter: dc,
+		}
+		getArgs.Token = token
+
+		var getReply structs.IndexedConfigEntries
+		if err := s.agent.RPC("ConfigEntry.Get", &getArgs, &getReply); err != nil {
+			// A real failure to read current state (ACL denied, RPC error,
+			// unknown datacenter, ...) means we can't honestly report what
+			// would happen, so surface it instead of guessing "created".
+			return nil, err
+		}
+
+		switch {
+		case len(getReply.Entries) == 0:
+			result.Action = "created"
+			result.Diff = diffConfigEntry(nil, entry)
+		case configEntriesEqual(getReply.Entries[0], entry):
+			result.Action = "unchanged"
+		default:
+			result.Action = "updated"
+			result.Diff = diffConfigEntry(getReply.Entries[0], entry)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// configEntriesEqual reports whether stored and incoming describe the same
+// entry, ignoring the RaftIndex: incoming always carries a zero-valued index
+// since callers never submit one, so comparing it verbatim against the
+// stored entry's real CreateIndex/ModifyIndex would report every no-op apply
+// as "updated".
+func configEntriesEqual(stored, incoming structs.ConfigEntry) bool {
+	storedIndex := *stored.GetRaftIndex()
+	*stored.GetRaftIndex() = structs.RaftIndex{}
+	defer func() { *stored.GetRaftIndex() = storedIndex }()
+
+	return reflect.DeepEqual(stored, incoming)
+}
+
+// diffConfigEntry renders a minimal line-oriented diff between the currently
+// stored entry (nil if it doesn't exist yet) and the incoming entry, both
+// rendered as indented JSON with their RaftIndex stripped so the diff only
+// reflects actual content changes.
+func diffConfigEntry(stored, incoming structs.ConfigEntry) string {
+	var oldLines []string
+	if stored != nil {
+		storedIndex := *stored.GetRaftIndex()
+		*stored.GetRaftIndex() = structs.RaftIndex{}
+		oldLines = jsonLines(stored)
+		*stored.GetRaftIndex() = storedIndex
+	}
+	newLines := jsonLines(incoming)
+
+	old := make(map[string]bool, len(oldLines))
+	for _, l := range oldLines {
+		old[l] = true
+	}
+	cur := make(map[string]bool, len(newLines))
+	for _, l := range newLines {
+		cur[l] = true
+	}
+
+	var buf bytes.Buffer
+	for _, l := range oldLines {
+		if !cur[l] {
+			fmt.Fprintf(&buf, "-%s\n", l)
+		}
+	}
+	for _, l := range newLines {
+		if !old[l] {
+			fmt.Fprintf(&buf, "+%s\n", l)
+		}
+	}
+	return buf.String()
+}
+
+func jsonLines(entry structs.ConfigEntry) []string {
+	b, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(b), "\n")
 }
\ No newline at end of file
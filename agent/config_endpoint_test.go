@@ -0,0 +1,148 @@
+package agent
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigEntriesEqual_IgnoresRaftIndex(t *testing.T) {
+	stored := &structs.ServiceConfigEntry{
+		Kind:     structs.ServiceDefaults,
+		Name:     "web",
+		Protocol: "http",
+	}
+	stored.RaftIndex = structs.RaftIndex{CreateIndex: 5, ModifyIndex: 9}
+
+	incoming := &structs.ServiceConfigEntry{
+		Kind:     structs.ServiceDefaults,
+		Name:     "web",
+		Protocol: "http",
+	}
+
+	require.True(t, configEntriesEqual(stored, incoming), "entries with identical content but differing RaftIndex should compare equal")
+	require.Equal(t, uint64(5), stored.CreateIndex, "configEntriesEqual must not mutate the stored entry")
+	require.Equal(t, uint64(9), stored.ModifyIndex, "configEntriesEqual must not mutate the stored entry")
+
+	incoming.Protocol = "grpc"
+	require.False(t, configEntriesEqual(stored, incoming), "entries with different content should not compare equal")
+}
+
+func TestConfigApplyDryRun_Unchanged(t *testing.T) {
+	stored := &structs.ServiceConfigEntry{
+		Kind:     structs.ServiceDefaults,
+		Name:     "web",
+		Protocol: "http",
+	}
+	stored.RaftIndex = structs.RaftIndex{CreateIndex: 5, ModifyIndex: 9}
+
+	incoming := &structs.ServiceConfigEntry{
+		Kind:     structs.ServiceDefaults,
+		Name:     "web",
+		Protocol: "http",
+	}
+
+	require.NoError(t, incoming.Normalize())
+	require.NoError(t, incoming.Validate())
+	require.True(t, configEntriesEqual(stored, incoming), "a decoded entry identical in content to what's stored should report unchanged")
+}
+
+func TestDiffConfigEntry(t *testing.T) {
+	stored := &structs.ServiceConfigEntry{
+		Kind:     structs.ServiceDefaults,
+		Name:     "web",
+		Protocol: "http",
+	}
+	stored.RaftIndex = structs.RaftIndex{CreateIndex: 5, ModifyIndex: 9}
+
+	incoming := &structs.ServiceConfigEntry{
+		Kind:     structs.ServiceDefaults,
+		Name:     "web",
+		Protocol: "grpc",
+	}
+
+	diff := diffConfigEntry(stored, incoming)
+	require.Contains(t, diff, `-  "Protocol": "http"`)
+	require.Contains(t, diff, `+  "Protocol": "grpc"`)
+	require.NotContains(t, diff, "CreateIndex", "diff should not include RaftIndex churn")
+	require.Equal(t, uint64(5), stored.CreateIndex, "diffConfigEntry must not mutate the stored entry")
+}
+
+func TestSplitYAMLDocs(t *testing.T) {
+	input := []byte("Kind: service-defaults\nName: web\n---\nKind: proxy-defaults\nName: global\n")
+
+	docs := splitYAMLDocs(input)
+	require.Len(t, docs, 2)
+	require.Contains(t, string(docs[0]), "Name: web")
+	require.Contains(t, string(docs[1]), "Name: global")
+}
+
+func TestDecodeConfigEntries_YAMLMultiDoc(t *testing.T) {
+	body := []byte("Kind: service-defaults\nName: web\nProtocol: http\n---\nKind: proxy-defaults\nName: global\n")
+
+	entries, warnings, err := decodeConfigEntries("application/yaml", body, configValidationWarn)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Len(t, entries, 2)
+	require.Equal(t, "web", entries[0].GetName())
+	require.Equal(t, "global", entries[1].GetName())
+}
+
+func TestDecodeConfigEntries_JSONSingle(t *testing.T) {
+	body := []byte(`{"Kind": "service-defaults", "Name": "web", "Protocol": "http"}`)
+
+	entries, _, err := decodeConfigEntries("application/json", body, configValidationWarn)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "web", entries[0].GetName())
+}
+
+// TestDecodeConfigEntries_YAMLContentTypeWithParams guards against a client
+// sending a perfectly valid "Content-Type: application/yaml; charset=utf-8"
+// header falling through to the JSON branch and failing to decode.
+func TestDecodeConfigEntries_YAMLContentTypeWithParams(t *testing.T) {
+	body := []byte("Kind: service-defaults\nName: web\nProtocol: http\n")
+
+	entries, _, err := decodeConfigEntries("application/yaml; charset=utf-8", body, configValidationWarn)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "web", entries[0].GetName())
+}
+
+// TestIsConfigApplyBatchPath pins down the routing decision that determines
+// whether a POST under the "/v1/config/" prefix reaches ConfigApplyBatch.
+// Config's method switch sends every POST to configPost, which uses this
+// check to pick ConfigApplyBatch; the switch itself is not exercised here.
+func TestIsConfigApplyBatchPath(t *testing.T) {
+	require.True(t, isConfigApplyBatchPath("/v1/config/apply"))
+	require.False(t, isConfigApplyBatchPath("/v1/config/service-defaults"))
+	require.False(t, isConfigApplyBatchPath("/v1/config/service-defaults/web"))
+}
+
+func TestConfig_MethodNotAllowed(t *testing.T) {
+	s := &HTTPServer{}
+	req := httptest.NewRequest("PATCH", "/v1/config/service-defaults/web", nil)
+	resp := httptest.NewRecorder()
+
+	_, err := s.Config(resp, req)
+	require.Error(t, err)
+
+	mnae, ok := err.(MethodNotAllowedError)
+	require.True(t, ok, "expected a MethodNotAllowedError, got %T", err)
+	require.Contains(t, mnae.Allow, "GET")
+	require.Contains(t, mnae.Allow, "POST")
+	require.Contains(t, mnae.Allow, "DELETE")
+}
+
+func TestParseDryRun(t *testing.T) {
+	require.True(t, parseDryRun(httptest.NewRequest("POST", "/v1/config/apply?dry-run=true", nil)))
+	require.False(t, parseDryRun(httptest.NewRequest("POST", "/v1/config/apply", nil)))
+	require.False(t, parseDryRun(httptest.NewRequest("POST", "/v1/config/apply?dry-run=bogus", nil)))
+}
+
+func TestParseStream(t *testing.T) {
+	require.True(t, parseStream(httptest.NewRequest("GET", "/v1/config/service-defaults/web?stream=true", nil)))
+	require.False(t, parseStream(httptest.NewRequest("GET", "/v1/config/service-defaults/web", nil)))
+}
@@ -0,0 +1,50 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeConfigEntry_ValidationModes(t *testing.T) {
+	// "Protcol" is a misspelling of "Protocol" and should never be applied.
+	raw := map[string]interface{}{
+		"Kind":    "service-defaults",
+		"Name":    "web",
+		"Protcol": "grpc",
+	}
+
+	t.Run("lax drops unknown fields silently", func(t *testing.T) {
+		entry, warnings, err := decodeConfigEntry(raw, configValidationLax)
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+		require.Equal(t, "web", entry.GetName())
+	})
+
+	t.Run("warn drops unknown fields but reports them", func(t *testing.T) {
+		entry, warnings, err := decodeConfigEntry(raw, configValidationWarn)
+		require.NoError(t, err)
+		require.Equal(t, "web", entry.GetName())
+		require.Contains(t, warnings, "Protcol")
+	})
+
+	t.Run("strict rejects unknown fields", func(t *testing.T) {
+		_, _, err := decodeConfigEntry(raw, configValidationStrict)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Protcol")
+	})
+
+	t.Run("known fields decode cleanly in every mode", func(t *testing.T) {
+		clean := map[string]interface{}{
+			"Kind":     "service-defaults",
+			"Name":     "web",
+			"Protocol": "grpc",
+		}
+		for _, mode := range []configValidationMode{configValidationStrict, configValidationWarn, configValidationLax} {
+			entry, warnings, err := decodeConfigEntry(clean, mode)
+			require.NoError(t, err)
+			require.Empty(t, warnings)
+			require.Equal(t, "web", entry.GetName())
+		}
+	})
+}
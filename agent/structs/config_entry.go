@@ -0,0 +1,191 @@
+package structs
+
+import "fmt"
+
+const (
+	ServiceDefaults string = "service-defaults"
+	ProxyDefaults   string = "proxy-defaults"
+)
+
+// ConfigEntry is the interface for centralized configuration stored in Raft.
+// Currently only service-defaults and proxy-defaults are supported.
+type ConfigEntry interface {
+	GetKind() string
+	GetName() string
+
+	// GetRaftIndex is used to compare raft indices when applying config
+	// entries and is required to satisfy the FSM registration requirements.
+	GetRaftIndex() *RaftIndex
+
+	// Normalize fills in any missing defaults before the entry is validated
+	// or stored, e.g. defaulting Protocol to "tcp".
+	Normalize() error
+
+	// Validate checks that the entry is well-formed, returning an error
+	// describing the first problem found.
+	Validate() error
+}
+
+// ConfigEntryOp is the type of operation being performed when applying a
+// config entry via Raft.
+type ConfigEntryOp string
+
+const (
+	ConfigEntryUpsert ConfigEntryOp = "upsert"
+	ConfigEntryDelete ConfigEntryOp = "delete"
+)
+
+// ConfigEntryRequest is used when creating/updating/deleting a config entry.
+type ConfigEntryRequest struct {
+	Op         ConfigEntryOp
+	Datacenter string
+	Entry      ConfigEntry
+	Token      string `json:"-"`
+
+	// CAS is used to indicate that the write should only happen if the
+	// entry's current ModifyIndex matches CASIndex. CASIndex of 0 means
+	// the write should only succeed if the entry does not already exist,
+	// mirroring the KVS CAS convention.
+	CAS      bool
+	CASIndex uint64
+
+	WriteRequest
+}
+
+func (c *ConfigEntryRequest) RequestDatacenter() string {
+	return c.Datacenter
+}
+
+// ConfigEntryQuery is used when fetching/listing a config entry.
+type ConfigEntryQuery struct {
+	Kind       string
+	Name       string
+	Datacenter string
+
+	QueryOptions
+}
+
+func (c *ConfigEntryQuery) RequestDatacenter() string {
+	return c.Datacenter
+}
+
+// IndexedConfigEntries is used to return a set of config entries along with
+// the last Raft index that affected them.
+type IndexedConfigEntries struct {
+	Kind    string
+	Entries []ConfigEntry
+
+	QueryMeta
+}
+
+// MakeConfigEntry returns a new, empty ConfigEntry for the given kind, or an
+// error if the kind is not registered.
+func MakeConfigEntry(kind, name string) (ConfigEntry, error) {
+	switch kind {
+	case ServiceDefaults:
+		return &ServiceConfigEntry{Kind: kind, Name: name}, nil
+	case ProxyDefaults:
+		return &ProxyConfigEntry{Kind: kind, Name: name}, nil
+	default:
+		return nil, fmt.Errorf("invalid config entry kind: %s", kind)
+	}
+}
+
+// ServiceConfigEntry manages the configuration for a service of the given
+// name across the whole cluster.
+type ServiceConfigEntry struct {
+	Kind     string
+	Name     string
+	Protocol string
+
+	RaftIndex
+}
+
+func (e *ServiceConfigEntry) GetKind() string          { return ServiceDefaults }
+func (e *ServiceConfigEntry) GetName() string          { return e.Name }
+func (e *ServiceConfigEntry) GetRaftIndex() *RaftIndex { return &e.RaftIndex }
+
+// Normalize defaults Protocol to "tcp" when it's left unset.
+func (e *ServiceConfigEntry) Normalize() error {
+	if e.Protocol == "" {
+		e.Protocol = "tcp"
+	}
+	return nil
+}
+
+// Validate checks that Name is set and Protocol is one of the supported
+// L4/L7 protocols.
+func (e *ServiceConfigEntry) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	switch e.Protocol {
+	case "tcp", "http", "http2", "grpc":
+	default:
+		return fmt.Errorf("invalid protocol %q for service-defaults %q, must be one of tcp, http, http2, grpc", e.Protocol, e.Name)
+	}
+	return nil
+}
+
+// ProxyConfigEntry manages the global default proxy configuration for the
+// datacenter.
+type ProxyConfigEntry struct {
+	Kind   string
+	Name   string
+	Config map[string]interface{}
+
+	RaftIndex
+}
+
+func (e *ProxyConfigEntry) GetKind() string          { return ProxyDefaults }
+func (e *ProxyConfigEntry) GetName() string          { return e.Name }
+func (e *ProxyConfigEntry) GetRaftIndex() *RaftIndex { return &e.RaftIndex }
+
+// Normalize is a no-op for proxy-defaults; there are no optional fields to
+// default today.
+func (e *ProxyConfigEntry) Normalize() error {
+	return nil
+}
+
+// Validate checks that Name is set.
+func (e *ProxyConfigEntry) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	return nil
+}
+
+// ConfigEntryTxnOp is a single operation within a ConfigEntryTxnRequest,
+// applying one config entry as part of a larger atomic batch.
+type ConfigEntryTxnOp struct {
+	Op    ConfigEntryOp
+	Entry ConfigEntry
+}
+
+// ConfigEntryTxnRequest is used to apply a batch of config entries as a
+// single Raft transaction, either all of them commit or none do. This backs
+// the multi-document `apply -f` style HTTP endpoint.
+type ConfigEntryTxnRequest struct {
+	Datacenter string
+	Token      string `json:"-"`
+	Ops        []ConfigEntryTxnOp
+
+	WriteRequest
+}
+
+func (c *ConfigEntryTxnRequest) RequestDatacenter() string {
+	return c.Datacenter
+}
+
+// ConfigEntryTxnResponse is returned once every op in a ConfigEntryTxnRequest
+// has been committed.
+type ConfigEntryTxnResponse struct {
+	Results []ConfigEntryTxnOpResult
+}
+
+// ConfigEntryTxnOpResult reports what a single op in the batch did, in the
+// same order the ops were submitted.
+type ConfigEntryTxnOpResult struct {
+	Kind string
+	Name string
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// These mirror the kinds structs.MakeConfigEntry recognizes on the server
+// side. Add a kind here only once the server can actually store it;
+// otherwise Set/CAS always fail with "invalid config entry kind".
+const (
+	ServiceDefaults string = "service-defaults"
+	ProxyDefaults   string = "proxy-defaults"
+)
+
+// ConfigEntry is the interface implemented by each concrete kind of
+// centralized configuration entry (service-defaults, proxy-defaults, etc.),
+// mirroring structs.ConfigEntry on the server side.
+type ConfigEntry interface {
+	GetKind() string
+	GetName() string
+	GetCreateIndex() uint64
+	GetModifyIndex() uint64
+}
+
+// ServiceConfigEntry manages the configuration for a service of the given
+// name across the whole cluster.
+type ServiceConfigEntry struct {
+	Kind        string
+	Name        string
+	Protocol    string
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (s *ServiceConfigEntry) GetKind() string        { return ServiceDefaults }
+func (s *ServiceConfigEntry) GetName() string        { return s.Name }
+func (s *ServiceConfigEntry) GetCreateIndex() uint64 { return s.CreateIndex }
+func (s *ServiceConfigEntry) GetModifyIndex() uint64 { return s.ModifyIndex }
+
+// ProxyConfigEntry manages the global default proxy configuration for the
+// datacenter.
+type ProxyConfigEntry struct {
+	Kind        string
+	Name        string
+	Config      map[string]interface{}
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (p *ProxyConfigEntry) GetKind() string        { return ProxyDefaults }
+func (p *ProxyConfigEntry) GetName() string        { return p.Name }
+func (p *ProxyConfigEntry) GetCreateIndex() uint64 { return p.CreateIndex }
+func (p *ProxyConfigEntry) GetModifyIndex() uint64 { return p.ModifyIndex }
+
+// MakeConfigEntry returns a new, empty ConfigEntry for the given kind, or an
+// error if the kind is not registered. It mirrors structs.MakeConfigEntry on
+// the server side.
+func MakeConfigEntry(kind, name string) (ConfigEntry, error) {
+	switch kind {
+	case ServiceDefaults:
+		return &ServiceConfigEntry{Kind: kind, Name: name}, nil
+	case ProxyDefaults:
+		return &ProxyConfigEntry{Kind: kind, Name: name}, nil
+	default:
+		return nil, fmt.Errorf("invalid config entry kind: %s", kind)
+	}
+}
+
+// DecodeConfigEntry decodes a generic map-shaped config entry, such as one
+// produced by json.Unmarshal into a map[string]interface{}, into its
+// concrete ConfigEntry type by dispatching on the Kind discriminator.
+func DecodeConfigEntry(raw map[string]interface{}) (ConfigEntry, error) {
+	var entry ConfigEntry
+
+	kindVal, ok := raw["Kind"]
+	if !ok {
+		kindVal, ok = raw["kind"]
+	}
+	if !ok {
+		return nil, fmt.Errorf("payload does not contain a kind/Kind key at the top level")
+	}
+
+	kindStr, ok := kindVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("kind value in payload is not a string")
+	}
+
+	entry, err := MakeConfigEntry(kindStr, "")
+	if err != nil {
+		return nil, err
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
+		Result:     &entry,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, decoder.Decode(raw)
+}
+
+// configEntryWrapper round-trips a single ConfigEntry through JSON by
+// dispatching on its Kind discriminator, since ConfigEntry itself is an
+// interface and can't otherwise be unmarshaled directly. A []configEntryWrapper
+// decodes the Entries list of a `/v1/config/<kind>` response.
+type configEntryWrapper struct {
+	ConfigEntry
+}
+
+func (w *configEntryWrapper) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	entry, err := DecodeConfigEntry(raw)
+	if err != nil {
+		return err
+	}
+
+	w.ConfigEntry = entry
+	return nil
+}
+
+// ConfigEntries can be used to query the Config endpoints to read, write,
+// and delete centralized config entries, mirroring the KV/Catalog clients.
+type ConfigEntries struct {
+	c *Client
+}
+
+// ConfigEntries returns a handle to the config-entry endpoints.
+func (c *Client) ConfigEntries() *ConfigEntries {
+	return &ConfigEntries{c}
+}
+
+// Get returns a single config entry of the given kind/name.
+func (conf *ConfigEntries) Get(kind, name string, q *QueryOptions) (ConfigEntry, *QueryMeta, error) {
+	r := conf.c.newRequest("GET", fmt.Sprintf("/v1/config/%s/%s", kind, name))
+	r.setQueryOptions(q)
+
+	rtt, resp, err := requireOK(conf.c.doRequest(r))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out struct {
+		Entries []configEntryWrapper
+	}
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	if len(out.Entries) == 0 {
+		return nil, qm, fmt.Errorf("config entry not found for %q / %q", kind, name)
+	}
+
+	return out.Entries[0].ConfigEntry, qm, nil
+}
+
+// List returns all config entries of the given kind.
+func (conf *ConfigEntries) List(kind string, q *QueryOptions) ([]ConfigEntry, *QueryMeta, error) {
+	r := conf.c.newRequest("GET", "/v1/config/"+kind)
+	r.setQueryOptions(q)
+
+	rtt, resp, err := requireOK(conf.c.doRequest(r))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out struct {
+		Entries []configEntryWrapper
+	}
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]ConfigEntry, len(out.Entries))
+	for i, wrapped := range out.Entries {
+		entries[i] = wrapped.ConfigEntry
+	}
+	return entries, qm, nil
+}
+
+// Set creates or updates the given config entry.
+func (conf *ConfigEntries) Set(entry ConfigEntry, w *WriteOptions) (*WriteMeta, error) {
+	r := conf.c.newRequest("PUT", "/v1/config")
+	r.setWriteOptions(w)
+	r.obj = entry
+
+	rtt, resp, err := requireOK(conf.c.doRequest(r))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	wm := &WriteMeta{RequestTime: rtt}
+	return wm, nil
+}
+
+// CAS creates or updates the given config entry only if its current
+// ModifyIndex still matches index, returning whether the write took place.
+func (conf *ConfigEntries) CAS(entry ConfigEntry, index uint64, w *WriteOptions) (bool, *WriteMeta, error) {
+	r := conf.c.newRequest("PUT", "/v1/config")
+	r.setWriteOptions(w)
+	r.params.Set("cas", strconv.FormatUint(index, 10))
+	r.obj = entry
+
+	rtt, resp, err := requireOK(conf.c.doRequest(r))
+	if err != nil {
+		return false, nil, err
+	}
+	defer resp.Body.Close()
+
+	wm := &WriteMeta{RequestTime: rtt}
+
+	var wrote bool
+	if err := decodeBody(resp, &wrote); err != nil {
+		return false, nil, err
+	}
+	return wrote, wm, nil
+}
+
+// Delete removes the config entry of the given kind/name.
+func (conf *ConfigEntries) Delete(kind, name string, w *WriteOptions) (*WriteMeta, error) {
+	r := conf.c.newRequest("DELETE", fmt.Sprintf("/v1/config/%s/%s", kind, name))
+	r.setWriteOptions(w)
+
+	rtt, resp, err := requireOK(conf.c.doRequest(r))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	wm := &WriteMeta{RequestTime: rtt}
+	return wm, nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeConfigEntry(t *testing.T) {
+	t.Run("service-defaults", func(t *testing.T) {
+		raw := map[string]interface{}{
+			"Kind":     "service-defaults",
+			"Name":     "web",
+			"Protocol": "grpc",
+		}
+		entry, err := DecodeConfigEntry(raw)
+		require.NoError(t, err)
+
+		svc, ok := entry.(*ServiceConfigEntry)
+		require.True(t, ok)
+		require.Equal(t, "web", svc.Name)
+		require.Equal(t, "grpc", svc.Protocol)
+	})
+
+	t.Run("lowercase kind key", func(t *testing.T) {
+		raw := map[string]interface{}{
+			"kind": "proxy-defaults",
+			"Name": "global",
+		}
+		entry, err := DecodeConfigEntry(raw)
+		require.NoError(t, err)
+		require.Equal(t, ProxyDefaults, entry.GetKind())
+	})
+
+	t.Run("missing kind", func(t *testing.T) {
+		_, err := DecodeConfigEntry(map[string]interface{}{"Name": "web"})
+		require.Error(t, err)
+	})
+
+	t.Run("unrecognized kind", func(t *testing.T) {
+		_, err := DecodeConfigEntry(map[string]interface{}{"Kind": "service-router", "Name": "web"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid config entry kind")
+	})
+}
+
+func TestConfigEntryWrapper_UnmarshalJSON(t *testing.T) {
+	body := []byte(`{"Kind": "service-defaults", "Name": "web", "Protocol": "http"}`)
+
+	var wrapped configEntryWrapper
+	require.NoError(t, json.Unmarshal(body, &wrapped))
+	require.Equal(t, "web", wrapped.ConfigEntry.GetName())
+	require.Equal(t, ServiceDefaults, wrapped.ConfigEntry.GetKind())
+}
+
+func TestConfigEntryWrapper_UnmarshalJSONList(t *testing.T) {
+	body := []byte(`[
+		{"Kind": "service-defaults", "Name": "web", "Protocol": "http"},
+		{"Kind": "proxy-defaults", "Name": "global"}
+	]`)
+
+	var wrapped []configEntryWrapper
+	require.NoError(t, json.Unmarshal(body, &wrapped))
+	require.Len(t, wrapped, 2)
+	require.Equal(t, "web", wrapped[0].ConfigEntry.GetName())
+	require.Equal(t, "global", wrapped[1].ConfigEntry.GetName())
+}